@@ -15,9 +15,12 @@ package command
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -39,11 +42,37 @@ const (
 	ctYAML
 )
 
+// gzipMagic is the two-byte magic header of a gzip-compressed stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// ErrInvalidDescriptor is returned by getAPI when the supplied bytes could
+// not be parsed as an OpenAPI3 descriptor, so that callers can distinguish
+// a malformed document from an I/O failure reading or fetching it.
+var ErrInvalidDescriptor = errors.New("invalid OpenAPI3 descriptor document")
+
 var (
-	optGenVersion string
-	optOutputPath string
+	optGenVersions     []string
+	optGenVersion      string
+	optOutputPath      string
+	optOutputTypes     string
+	optIncludeResource []string
+	optExcludeResource []string
+	optIncludeTag      []string
+	optExcludeTag      []string
+	optTemplateDirs    []string
+	optInputFormat     string
 )
 
+// templateNames is the set of named templates the generator resolves
+// through templateSearchPath, in the order listTemplatesCmd reports them.
+var templateNames = []string{
+	"doc.go.tpl",
+	"groupversion_info.go.tpl",
+	"types.go.tpl",
+	"resource.go.tpl",
+	"conversion.go.tpl",
+}
+
 // apiCmd is the command that generates service API types
 var typesCmd = &cobra.Command{
 	Use:   "types <file>",
@@ -52,13 +81,87 @@ var typesCmd = &cobra.Command{
 }
 
 func init() {
-	typesCmd.PersistentFlags().StringVarP(
-		&optGenVersion, "version", "v", "v1alpha1", "the resource API Version to use when generating types",
+	typesCmd.PersistentFlags().StringSliceVarP(
+		&optGenVersions, "version", "v", []string{"v1alpha1"},
+		"the resource API Version to use when generating types. May be specified multiple times "+
+			"or as a comma-separated list (e.g. -v v1alpha1 -v v1beta1) to generate more than one "+
+			"version; the first version supplied is treated as the conversion hub",
 	)
 	typesCmd.PersistentFlags().StringVarP(
 		&optOutputPath, "output", "o", "", "path to output directory to send generated files. If empty, outputs all files to stdout",
 	)
+	typesCmd.PersistentFlags().StringVar(
+		&optOutputTypes, "output-types", "go", "comma-separated list of formats to emit for generated type definitions and resources (go,json,yaml)",
+	)
+	typesCmd.PersistentFlags().StringSliceVar(
+		&optIncludeResource, "include-resource", nil,
+		"glob pattern(s) matching resource Kinds to generate, case-insensitive. If unset, all resources are included",
+	)
+	typesCmd.PersistentFlags().StringSliceVar(
+		&optExcludeResource, "exclude-resource", nil,
+		"glob pattern(s) matching resource Kinds to skip, case-insensitive. Takes precedence over --include-resource",
+	)
+	typesCmd.PersistentFlags().StringSliceVar(
+		&optIncludeTag, "include-tag", nil,
+		"glob pattern(s) matching OpenAPI operation tags; only resources with a matching tag are generated. If unset, tags are not used to filter",
+	)
+	typesCmd.PersistentFlags().StringSliceVar(
+		&optExcludeTag, "exclude-tag", nil,
+		"glob pattern(s) matching OpenAPI operation tags; resources with a matching tag are skipped. Takes precedence over --include-tag",
+	)
+	typesCmd.PersistentFlags().StringVar(
+		&optInputFormat, "input-format", "auto",
+		"force the content type of the supplied OpenAPI3 descriptor instead of detecting it (auto|json|yaml)",
+	)
+	// --template-dir is shared by typesCmd and listTemplatesCmd (siblings, so
+	// it can't be a persistent flag of either alone), so it's registered on
+	// rootCmd instead.
+	rootCmd.PersistentFlags().StringArrayVar(
+		&optTemplateDirs, "template-dir", nil,
+		"directory to search for template overrides before falling back to the built-in templates. "+
+			"May be specified multiple times; earlier directories take precedence",
+	)
 	rootCmd.AddCommand(typesCmd)
+	rootCmd.AddCommand(listTemplatesCmd)
+}
+
+// templateSearchPath returns the ordered list of directories the template
+// package's loaders should search for overrides, earliest --template-dir
+// first; a template with no override in any of these directories falls
+// back to its built-in source.
+func templateSearchPath() []string {
+	return append([]string{}, optTemplateDirs...)
+}
+
+// listTemplatesCmd prints, for each named template the generator uses, the
+// directory it resolves to. It asks the template package's own
+// template.ResolveDir for the answer rather than re-deriving it, so its
+// output can never drift from what's actually loaded at generation time.
+var listTemplatesCmd = &cobra.Command{
+	Use:   "list-templates",
+	Short: "Prints the resolved source directory for each named template",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		searchPath := templateSearchPath()
+		for _, name := range templateNames {
+			dir := template.ResolveDir(searchPath, name)
+			if dir == "" {
+				dir = "(built-in)"
+			}
+			fmt.Printf("%-28s %s\n", name, dir)
+		}
+		return nil
+	},
+}
+
+// wantsOutputType returns true if the supplied format (e.g. "go", "json",
+// "yaml") was requested via --output-types.
+func wantsOutputType(format string) bool {
+	for _, t := range strings.Split(optOutputTypes, ",") {
+		if strings.EqualFold(strings.TrimSpace(t), format) {
+			return true
+		}
+	}
+	return false
 }
 
 // ensureOutputDir makes sure that the target output directory exists and
@@ -92,44 +195,221 @@ func ensureOutputDir() (bool, error) {
 	return true, nil
 }
 
+// ensureVersionOutputDir makes sure that the output directory for the given
+// version exists when optOutputPath is set, and returns the directory that
+// its files should be written to. When optOutputPath is empty (stdout
+// mode), it returns "" and is a noop. When only one version was requested,
+// files are written directly under optOutputPath, preserving the
+// single-version layout that existing callers (scripts, CI, Makefiles)
+// already expect; the optOutputPath/<version>/ nesting only kicks in once
+// more than one version is being generated.
+func ensureVersionOutputDir(version string) (string, error) {
+	if optOutputPath == "" {
+		return "", nil
+	}
+	dir := optOutputPath
+	if len(optGenVersions) > 1 {
+		dir = filepath.Join(optOutputPath, version)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
 // generateTypes generates the Go files for each resource in the AWS service
-// API.
+// API, once per requested API version. When more than one version is
+// requested, each version's files are written to their own
+// optOutputPath/<version>/ subdirectory and conversion stubs are generated
+// between the hub version (the first one supplied) and every other version.
+// With a single version (the default), files are written directly under
+// optOutputPath, matching the generator's pre-existing single-version
+// layout.
 func generateTypes(cmd *cobra.Command, args []string) error {
+	if len(optGenVersions) == 0 {
+		return fmt.Errorf("at least one API version must be supplied via --version.")
+	}
 	api, err := getAPI(args)
 	if err != nil {
 		return err
 	}
-	resources, err := resource.ResourcesFromAPI(api)
+	resources, err := resource.ResourcesFromAPI(api, pathWildcards(api))
 	if err != nil {
 		return err
 	}
-	typeDefs, err := resource.TypeDefsFromAPI(api, resources)
+	resources = filterResources(resources)
+	typeDefs, err := resource.TypeDefsFromAPI(api, resources, optGenVersions)
 	if err != nil {
 		return err
 	}
+	typeDefs = pruneUnreferencedTypeDefs(typeDefs, resources)
 
 	if _, err := ensureOutputDir(); err != nil {
 		return err
 	}
 
-	if err = writeDocGo(api); err != nil {
-		return err
+	hubVersion := optGenVersions[0]
+	for _, version := range optGenVersions {
+		optGenVersion = version
+		dir, err := ensureVersionOutputDir(version)
+		if err != nil {
+			return err
+		}
+
+		if err = writeDocGo(dir, api); err != nil {
+			return err
+		}
+
+		if err = writeGroupVersionInfoGo(dir, api); err != nil {
+			return err
+		}
+
+		if err = writeTypesGo(dir, typeDefs); err != nil {
+			return err
+		}
+
+		for _, res := range resources {
+			if err = writeResourceGo(dir, res); err != nil {
+				return err
+			}
+		}
+
+		if version != hubVersion {
+			if err = writeConversionGo(dir, hubVersion, version, typeDefs); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
 
-	if err = writeGroupVersionInfoGo(api); err != nil {
-		return err
+// matchesAnyGlob returns true if name matches any of the (case-insensitive)
+// glob patterns in patterns. An empty patterns slice matches nothing.
+func matchesAnyGlob(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range patterns {
+		if ok, err := filepath.Match(strings.ToLower(p), name); err == nil && ok {
+			return true
+		}
 	}
+	return false
+}
 
-	if err = writeTypesGo(typeDefs); err != nil {
-		return err
+// operationTags returns the set of OpenAPI operation tags for the
+// operations that actually produced res, i.e. res.SourceOperations. Large
+// AWS service descriptors group operations for a resource under a common
+// tag, which is what --include-tag/--exclude-tag filter on.
+func operationTags(res *resource.Resource) []string {
+	seen := map[string]bool{}
+	var tags []string
+	for _, op := range res.SourceOperations {
+		for _, tag := range op.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
 	}
+	return tags
+}
 
+// filterResources applies --include-resource/--exclude-resource and
+// --include-tag/--exclude-tag to resources, returning the filtered slice.
+// A summary of what was skipped, and why, is emitted to stderr.
+func filterResources(resources []*resource.Resource) []*resource.Resource {
+	if len(optIncludeResource) == 0 && len(optExcludeResource) == 0 &&
+		len(optIncludeTag) == 0 && len(optExcludeTag) == 0 {
+		return resources
+	}
+	var kept []*resource.Resource
 	for _, res := range resources {
-		if err = writeResourceGo(res); err != nil {
-			return err
+		if len(optExcludeResource) > 0 && matchesAnyGlob(optExcludeResource, res.Kind) {
+			fmt.Fprintf(os.Stderr, "skipping resource %s: matched --exclude-resource\n", res.Kind)
+			continue
+		}
+		if len(optIncludeResource) > 0 && !matchesAnyGlob(optIncludeResource, res.Kind) {
+			fmt.Fprintf(os.Stderr, "skipping resource %s: did not match --include-resource\n", res.Kind)
+			continue
+		}
+		tags := operationTags(res)
+		if len(optExcludeTag) > 0 && anyTagMatches(optExcludeTag, tags) {
+			fmt.Fprintf(os.Stderr, "skipping resource %s: matched --exclude-tag\n", res.Kind)
+			continue
+		}
+		if len(optIncludeTag) > 0 && !anyTagMatches(optIncludeTag, tags) {
+			fmt.Fprintf(os.Stderr, "skipping resource %s: did not match --include-tag\n", res.Kind)
+			continue
 		}
+		kept = append(kept, res)
 	}
-	return nil
+	return kept
+}
+
+// anyTagMatches returns true if any tag in tags matches any glob pattern.
+func anyTagMatches(patterns []string, tags []string) bool {
+	for _, tag := range tags {
+		if matchesAnyGlob(patterns, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneUnreferencedTypeDefs drops TypeDefs that no longer correspond to any
+// of the resources left standing after filterResources, so that excluded
+// resources don't leave orphaned type definitions behind in types.go. Spec
+// and Status TypeDef names are generated deterministically by
+// resource.TypeDefsFromAPI (resource.SpecTypeDefName/StatusTypeDefName), so
+// membership is an exact-match lookup rather than a name heuristic.
+func pruneUnreferencedTypeDefs(typeDefs []*resource.TypeDef, resources []*resource.Resource) []*resource.TypeDef {
+	if len(optIncludeResource) == 0 && len(optExcludeResource) == 0 &&
+		len(optIncludeTag) == 0 && len(optExcludeTag) == 0 {
+		return typeDefs
+	}
+	referenced := make(map[string]bool, len(resources)*2)
+	for _, res := range resources {
+		referenced[resource.SpecTypeDefName(res.Kind)] = true
+		referenced[resource.StatusTypeDefName(res.Kind)] = true
+	}
+	var kept []*resource.TypeDef
+	for _, td := range typeDefs {
+		if !referenced[td.Name] {
+			fmt.Fprintf(os.Stderr, "pruning unreferenced type definition %s\n", td.Name)
+			continue
+		}
+		kept = append(kept, td)
+	}
+	return kept
+}
+
+// divergentFields returns, for each TypeDef, the names of fields whose
+// presence differs between hubVersion and spokeVersion -- the fields a
+// conversion between the two versions cannot copy straight across.
+func divergentFields(typeDefs []*resource.TypeDef, hubVersion, spokeVersion string) map[string][]string {
+	diff := make(map[string][]string)
+	for _, td := range typeDefs {
+		var names []string
+		for _, f := range td.Fields {
+			if resource.FieldAppliesToVersion(f, hubVersion) != resource.FieldAppliesToVersion(f, spokeVersion) {
+				names = append(names, f.Name)
+			}
+		}
+		if len(names) > 0 {
+			diff[td.Name] = names
+		}
+	}
+	return diff
+}
+
+// pathWildcards maps every path in api.Paths to its ordered `{name}`
+// wildcard segments, for resource.ResourcesFromAPI to use when marking
+// path-derived fields and detecting subresources. See resource.ExtractWildcards.
+func pathWildcards(api *openapi3.Swagger) map[string][]string {
+	wildcards := make(map[string][]string, len(api.Paths))
+	for path := range api.Paths {
+		wildcards[path] = resource.ExtractWildcards(path)
+	}
+	return wildcards
 }
 
 func apiGroupFromSwagger(api *openapi3.Swagger) string {
@@ -142,130 +422,204 @@ func apiGroupFromSwagger(api *openapi3.Swagger) string {
 	return strings.Replace(apiGroup, "\"", "", -1)
 }
 
-func writeDocGo(api *openapi3.Swagger) error {
+func writeDocGo(dir string, api *openapi3.Swagger) error {
 	var b bytes.Buffer
 	apiGroup := apiGroupFromSwagger(api)
 	vars := &template.DocTemplateVars{
 		APIVersion: optGenVersion,
 		APIGroup:   apiGroup,
 	}
-	tpl, err := template.NewDocTemplate(templatesDir)
+	tpl, err := template.NewDocTemplate(templateSearchPath())
 	if err != nil {
 		return err
 	}
 	if err := tpl.Execute(&b, vars); err != nil {
 		return err
 	}
-	if optOutputPath == "" {
-		fmt.Println("============================= doc.go ======================================")
-		fmt.Println(strings.TrimSpace(b.String()))
-		return nil
-	} else {
-		path := filepath.Join(optOutputPath, "doc.go")
-		return ioutil.WriteFile(path, b.Bytes(), 0666)
-	}
+	return writeArtifact(dir, "doc.go", "go", b.Bytes())
 }
 
-func writeGroupVersionInfoGo(api *openapi3.Swagger) error {
+func writeGroupVersionInfoGo(dir string, api *openapi3.Swagger) error {
 	var b bytes.Buffer
 	apiGroup := apiGroupFromSwagger(api)
 	vars := &template.GroupVersionInfoTemplateVars{
 		APIVersion: optGenVersion,
 		APIGroup:   apiGroup,
 	}
-	tpl, err := template.NewGroupVersionInfoTemplate(templatesDir)
+	tpl, err := template.NewGroupVersionInfoTemplate(templateSearchPath())
 	if err != nil {
 		return err
 	}
 	if err := tpl.Execute(&b, vars); err != nil {
 		return err
 	}
-	if optOutputPath == "" {
-		fmt.Println("============================= groupversion_info.go ======================================")
-		fmt.Println(strings.TrimSpace(b.String()))
-		return nil
-	} else {
-		path := filepath.Join(optOutputPath, "groupversion_info.go")
-		return ioutil.WriteFile(path, b.Bytes(), 0666)
-	}
+	return writeArtifact(dir, "groupversion_info.go", "go", b.Bytes())
 }
 
-func writeTypesGo(typeDefs []*resource.TypeDef) error {
-	vars := &template.TypesTemplateVars{
-		APIVersion: optGenVersion,
-		TypeDefs:   typeDefs,
+func writeTypesGo(dir string, typeDefs []*resource.TypeDef) error {
+	if wantsOutputType("go") {
+		vars := &template.TypesTemplateVars{
+			APIVersion: optGenVersion,
+			TypeDefs:   typeDefs,
+		}
+		var b bytes.Buffer
+		tpl, err := template.NewTypesTemplate(templateSearchPath())
+		if err != nil {
+			return err
+		}
+		if err := tpl.Execute(&b, vars); err != nil {
+			return err
+		}
+		if err := writeArtifact(dir, "types.go", "go", b.Bytes()); err != nil {
+			return err
+		}
 	}
-	var b bytes.Buffer
-	tpl, err := template.NewTypesTemplate(templatesDir)
-	if err != nil {
-		return err
+	if wantsOutputType("json") {
+		b, err := json.MarshalIndent(typeDefs, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := writeArtifact(dir, "types.json", "json", b); err != nil {
+			return err
+		}
 	}
-	if err := tpl.Execute(&b, vars); err != nil {
-		return err
+	if wantsOutputType("yaml") {
+		b, err := yaml.Marshal(typeDefs)
+		if err != nil {
+			return err
+		}
+		if err := writeArtifact(dir, "types.yaml", "yaml", b); err != nil {
+			return err
+		}
 	}
-	if optOutputPath == "" {
-		fmt.Println("============================= types.go ======================================")
-		fmt.Println(strings.TrimSpace(b.String()))
-		return nil
-	} else {
-		path := filepath.Join(optOutputPath, "types.go")
-		return ioutil.WriteFile(path, b.Bytes(), 0666)
+	return nil
+}
+
+func writeResourceGo(dir string, res *resource.Resource) error {
+	resBaseName := strcase.ToSnake(res.Kind)
+	if wantsOutputType("go") {
+		vars := &template.ResourceTemplateVars{
+			APIVersion: optGenVersion,
+			Resource:   res,
+		}
+		var b bytes.Buffer
+		tpl, err := template.NewResourceTemplate(templateSearchPath())
+		if err != nil {
+			return err
+		}
+		if err := tpl.Execute(&b, vars); err != nil {
+			return err
+		}
+		if err := writeArtifact(dir, resBaseName+".go", "go", b.Bytes()); err != nil {
+			return err
+		}
 	}
+	if wantsOutputType("json") {
+		b, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := writeArtifact(dir, resBaseName+".json", "json", b); err != nil {
+			return err
+		}
+	}
+	if wantsOutputType("yaml") {
+		b, err := yaml.Marshal(res)
+		if err != nil {
+			return err
+		}
+		if err := writeArtifact(dir, resBaseName+".yaml", "yaml", b); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func writeResourceGo(res *resource.Resource) error {
-	vars := &template.ResourceTemplateVars{
-		APIVersion: optGenVersion,
-		Resource:   res,
+// writeConversionGo generates the zz_conversion.go stub for a spoke
+// version, listing for each TypeDef the fields whose presence differs from
+// the hub version; the stub defines no conversion methods. Only a spoke
+// version (one that isn't the hub) has a conversion stub generated for it.
+func writeConversionGo(dir string, hubVersion, spokeVersion string, typeDefs []*resource.TypeDef) error {
+	vars := &template.ConversionTemplateVars{
+		HubVersion:      hubVersion,
+		SpokeVersion:    spokeVersion,
+		TypeDefs:        typeDefs,
+		DivergentFields: divergentFields(typeDefs, hubVersion, spokeVersion),
 	}
 	var b bytes.Buffer
-	tpl, err := template.NewResourceTemplate(templatesDir)
+	tpl, err := template.NewConversionTemplate(templateSearchPath())
 	if err != nil {
 		return err
 	}
 	if err := tpl.Execute(&b, vars); err != nil {
 		return err
 	}
-	resFileName := strcase.ToSnake(res.Kind) + ".go"
-	if optOutputPath == "" {
-		fmt.Printf("============================= %s ======================================\n", resFileName)
-		fmt.Println(strings.TrimSpace(b.String()))
+	return writeArtifact(dir, "zz_conversion.go", "go", b.Bytes())
+}
+
+// writeArtifact writes a generated artifact's contents to dir/name, or,
+// when dir is empty (stdout mode), echoes it to stdout behind a banner
+// identifying the file name and content type.
+func writeArtifact(dir string, name string, contentType string, b []byte) error {
+	if dir == "" && optOutputPath == "" {
+		label := name
+		if len(optGenVersions) > 1 {
+			label = optGenVersion + "/" + name
+		}
+		fmt.Printf("============================= %s (%s) ======================================\n", label, contentType)
+		fmt.Println(strings.TrimSpace(string(b)))
 		return nil
-	} else {
-		path := filepath.Join(optOutputPath, resFileName)
-		return ioutil.WriteFile(path, b.Bytes(), 0666)
 	}
+	path := filepath.Join(dir, name)
+	return ioutil.WriteFile(path, b, 0666)
 }
 
 // getAPI returns an OpenAPI3 Swagger object representing the API from
-// either STDIN or an input file
+// either STDIN, a local file path, or an HTTP(S) URL. The descriptor may be
+// gzip-compressed. Content type is normally detected from the file
+// extension or a byte-sniff heuristic, but can be forced with
+// --input-format for STDIN or other ambiguous sources.
 func getAPI(args []string) (*openapi3.Swagger, error) {
 	var b []byte
 	var err error
-	contentType := ctUnknown
+	contentType := inputFormatContentType()
 	switch len(args) {
 	case 0:
 		if b, err = ioutil.ReadAll(os.Stdin); err != nil {
 			return nil, fmt.Errorf("expected OpenAPI3 descriptor document either via STDIN or path argument.")
 		}
 	case 1:
-		fp := filepath.Clean(args[0])
-		ext := filepath.Ext(fp)
-		switch ext {
-		case "json":
-			contentType = ctJSON
-		case "yaml", "yml":
-			contentType = ctYAML
-		}
-		if b, err = ioutil.ReadFile(fp); err != nil {
-			return nil, err
+		source := args[0]
+		if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+			if b, err = fetchAPI(source); err != nil {
+				return nil, err
+			}
+		} else {
+			fp := filepath.Clean(source)
+			if contentType == ctUnknown {
+				switch filepath.Ext(fp) {
+				case ".json":
+					contentType = ctJSON
+				case ".yaml", ".yml":
+					contentType = ctYAML
+				}
+			}
+			if b, err = ioutil.ReadFile(fp); err != nil {
+				return nil, err
+			}
 		}
 	default:
 		return nil, fmt.Errorf("expected OpenAPI3 descriptor document either via STDIN or path argument.")
 	}
 
+	if len(b) >= 2 && bytes.Equal(b[:2], gzipMagic) {
+		if b, err = gunzip(b); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDescriptor, err)
+		}
+	}
+
 	if len(b) < 2 {
-		return nil, fmt.Errorf("expected OpenAPI3 descriptor document but got '%s'.", string(b))
+		return nil, fmt.Errorf("%w: expected OpenAPI3 descriptor document but got '%s'", ErrInvalidDescriptor, string(b))
 	}
 
 	var jsonb []byte = b
@@ -281,7 +635,45 @@ func getAPI(args []string) (*openapi3.Swagger, error) {
 
 	api, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(jsonb)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDescriptor, err)
 	}
 	return api, nil
 }
+
+// inputFormatContentType translates the --input-format flag into a
+// contentType, returning ctUnknown (triggering auto-detection) for "auto"
+// or an unrecognized value.
+func inputFormatContentType() contentType {
+	switch strings.ToLower(optInputFormat) {
+	case "json":
+		return ctJSON
+	case "yaml", "yml":
+		return ctYAML
+	default:
+		return ctUnknown
+	}
+}
+
+// fetchAPI retrieves an OpenAPI3 descriptor published at a remote HTTP(S)
+// URL, such as one of the AWS service models.
+func fetchAPI(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// gunzip decompresses a gzip-compressed OpenAPI3 descriptor.
+func gunzip(b []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,266 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/aws/aws-service-operator-k8s/pkg/resource"
+)
+
+func TestWantsOutputType(t *testing.T) {
+	prev := optOutputTypes
+	defer func() { optOutputTypes = prev }()
+
+	tests := []struct {
+		name       string
+		outputType string
+		format     string
+		want       bool
+	}{
+		{"exact match", "go", "go", true},
+		{"case insensitive", "GO", "go", true},
+		{"one of several", "go,json,yaml", "json", true},
+		{"padded list", "go, json , yaml", "json", true},
+		{"not requested", "go,json", "yaml", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			optOutputTypes = tt.outputType
+			if got := wantsOutputType(tt.format); got != tt.want {
+				t.Errorf("wantsOutputType(%q) with optOutputTypes=%q = %v, want %v", tt.format, tt.outputType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateTypesRejectsEmptyVersionList(t *testing.T) {
+	prev := optGenVersions
+	defer func() { optGenVersions = prev }()
+
+	optGenVersions = []string{}
+	if err := generateTypes(nil, nil); err == nil {
+		t.Fatal("generateTypes() with an empty --version list: expected an error, got nil")
+	}
+}
+
+func TestDivergentFields(t *testing.T) {
+	typeDefs := []*resource.TypeDef{
+		{
+			Name: "PolicySpec",
+			Fields: []*resource.Field{
+				{Name: "Name"},
+				{Name: "RoleName", Versions: []string{"v1beta1"}},
+			},
+		},
+		{
+			Name: "PolicyStatus",
+			Fields: []*resource.Field{
+				{Name: "ACKResourceMetadata"},
+			},
+		},
+	}
+
+	got := divergentFields(typeDefs, "v1alpha1", "v1beta1")
+
+	want := map[string][]string{"PolicySpec": {"RoleName"}}
+	if len(got) != len(want) || len(got["PolicySpec"]) != 1 || got["PolicySpec"][0] != "RoleName" {
+		t.Errorf("divergentFields() = %#v, want %#v", got, want)
+	}
+	if _, ok := got["PolicyStatus"]; ok {
+		t.Errorf("divergentFields() unexpectedly flagged PolicyStatus, whose fields apply to every version")
+	}
+}
+
+func TestOperationTags(t *testing.T) {
+	res := &resource.Resource{
+		Kind: "Policy",
+		SourceOperations: []*openapi3.Operation{
+			{Tags: []string{"Policies", "Identity"}},
+			{Tags: []string{"Policies"}},
+		},
+	}
+
+	got := operationTags(res)
+	want := []string{"Policies", "Identity"}
+	if len(got) != len(want) {
+		t.Fatalf("operationTags() = %#v, want %#v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("operationTags()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAnyTagMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		tags     []string
+		want     bool
+	}{
+		{"matching pattern", []string{"Polic*"}, []string{"Policies"}, true},
+		{"no match", []string{"Buckets"}, []string{"Policies"}, false},
+		{"no tags", []string{"Polic*"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := anyTagMatches(tt.patterns, tt.tags); got != tt.want {
+				t.Errorf("anyTagMatches(%v, %v) = %v, want %v", tt.patterns, tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneUnreferencedTypeDefs(t *testing.T) {
+	prev := optIncludeResource
+	defer func() { optIncludeResource = prev }()
+	optIncludeResource = []string{"Policy"}
+
+	resources := []*resource.Resource{{Kind: "Policy"}}
+	typeDefs := []*resource.TypeDef{
+		{Name: resource.SpecTypeDefName("Policy")},
+		{Name: resource.StatusTypeDefName("Policy")},
+		{Name: resource.SpecTypeDefName("Role")},
+	}
+
+	got := pruneUnreferencedTypeDefs(typeDefs, resources)
+	if len(got) != 2 {
+		t.Fatalf("pruneUnreferencedTypeDefs() kept %d TypeDefs, want 2: %#v", len(got), got)
+	}
+	for _, td := range got {
+		if td.Name == resource.SpecTypeDefName("Role") {
+			t.Errorf("pruneUnreferencedTypeDefs() kept %q, which belongs to an excluded resource", td.Name)
+		}
+	}
+}
+
+const minimalOpenAPIJSON = `{"openapi":"3.0.0","info":{"title":"t","version":"1"},"paths":{}}`
+const minimalOpenAPIYAML = "openapi: \"3.0.0\"\ninfo:\n  title: t\n  version: \"1\"\npaths: {}\n"
+
+func writeTempFile(t *testing.T, name string, b []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, b, 0666); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGetAPIDetectsContentTypeFromExtension(t *testing.T) {
+	prev := optInputFormat
+	defer func() { optInputFormat = prev }()
+	optInputFormat = "auto"
+
+	jsonPath := writeTempFile(t, "api.json", []byte(minimalOpenAPIJSON))
+	if _, err := getAPI([]string{jsonPath}); err != nil {
+		t.Errorf("getAPI(%q) = %v, want nil error", jsonPath, err)
+	}
+
+	yamlPath := writeTempFile(t, "api.yaml", []byte(minimalOpenAPIYAML))
+	if _, err := getAPI([]string{yamlPath}); err != nil {
+		t.Errorf("getAPI(%q) = %v, want nil error", yamlPath, err)
+	}
+}
+
+func TestGetAPIDecompressesGzip(t *testing.T) {
+	prev := optInputFormat
+	defer func() { optInputFormat = prev }()
+	optInputFormat = "auto"
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(minimalOpenAPIJSON)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := writeTempFile(t, "api.json.gz", buf.Bytes())
+	if _, err := getAPI([]string{path}); err != nil {
+		t.Errorf("getAPI(%q) = %v, want nil error", path, err)
+	}
+}
+
+func TestGetAPIInputFormatOverride(t *testing.T) {
+	prev := optInputFormat
+	defer func() { optInputFormat = prev }()
+	optInputFormat = "yaml"
+
+	// A YAML document saved with a .json extension would be auto-detected
+	// as JSON and fail to parse; --input-format must override that guess.
+	path := writeTempFile(t, "api.json", []byte(minimalOpenAPIYAML))
+	if _, err := getAPI([]string{path}); err != nil {
+		t.Errorf("getAPI(%q) with optInputFormat=yaml = %v, want nil error", path, err)
+	}
+}
+
+func TestGetAPIWrapsInvalidDescriptor(t *testing.T) {
+	prev := optInputFormat
+	defer func() { optInputFormat = prev }()
+	optInputFormat = "auto"
+
+	path := writeTempFile(t, "api.json", []byte("not a valid descriptor"))
+	_, err := getAPI([]string{path})
+	if !errors.Is(err, ErrInvalidDescriptor) {
+		t.Errorf("getAPI(%q) = %v, want an error wrapping ErrInvalidDescriptor", path, err)
+	}
+}
+
+func TestTemplateSearchPath(t *testing.T) {
+	prev := optTemplateDirs
+	defer func() { optTemplateDirs = prev }()
+
+	optTemplateDirs = []string{"/override/a", "/override/b"}
+	got := templateSearchPath()
+	want := []string{"/override/a", "/override/b"}
+	if len(got) != len(want) {
+		t.Fatalf("templateSearchPath() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("templateSearchPath()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMatchesAnyGlob(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		value    string
+		want     bool
+	}{
+		{"exact match", []string{"policy"}, "Policy", true},
+		{"glob match", []string{"Role*"}, "RolePolicy", true},
+		{"no patterns", nil, "Policy", false},
+		{"no match", []string{"Bucket"}, "Policy", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAnyGlob(tt.patterns, tt.value); got != tt.want {
+				t.Errorf("matchesAnyGlob(%v, %q) = %v, want %v", tt.patterns, tt.value, got, tt.want)
+			}
+		})
+	}
+}
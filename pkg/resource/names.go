@@ -0,0 +1,51 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource
+
+import "strings"
+
+// Singularize reduces a lowercased English noun to an approximate singular
+// form (policies -> policy, repositories -> repository, buckets -> bucket,
+// addresses -> address), so that path segments that differ only by
+// ordinary REST pluralization can be compared to a resource Kind.
+func Singularize(s string) string {
+	s = strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(s, "ies") && len(s) > 3:
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "ses") && len(s) > 3:
+		return s[:len(s)-2]
+	case strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss") && len(s) > 1:
+		return s[:len(s)-1]
+	default:
+		return s
+	}
+}
+
+// literalPathSegments splits an OpenAPI path template into its literal
+// (i.e. non-wildcard) segments, lower-cased and with any trailing
+// extension (".json", ".yaml", ...) stripped.
+func literalPathSegments(path string) []string {
+	var segs []string
+	for _, part := range strings.Split(path, "/") {
+		if part == "" || strings.HasPrefix(part, "{") {
+			continue
+		}
+		if idx := strings.LastIndex(part, "."); idx > 0 {
+			part = part[:idx]
+		}
+		segs = append(segs, strings.ToLower(part))
+	}
+	return segs
+}
@@ -0,0 +1,60 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSingularize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain plural", "buckets", "bucket"},
+		{"ies plural", "policies", "policy"},
+		{"ses plural", "addresses", "address"},
+		{"already singular", "role", "role"},
+		{"ss not stripped", "access", "access"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Singularize(tt.in); got != tt.want {
+				t.Errorf("Singularize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiteralPathSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"no wildcards", "/roles/policies.json", []string{"roles", "policies"}},
+		{"with wildcards", "/roles/{name}/policies/{id}", []string{"roles", "policies"}},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := literalPathSegments(tt.path)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("literalPathSegments(%q) = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
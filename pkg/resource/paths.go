@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource
+
+// ExtractWildcards returns the ordered list of `{name}` path parameter
+// segments found in an OpenAPI path template, e.g. ExtractWildcards(
+// "/foo/{bar}/baz/{qux}.json") returns []string{"bar", "qux"}. Escaped
+// braces (`\{`, `\}`) are not treated as the start or end of a wildcard.
+// Paths with no wildcards return an empty slice.
+//
+// ResourcesFromAPI uses the returned names to mark the corresponding
+// resource fields as required and populated from the object's
+// metadata.name or an owner reference rather than from the request body,
+// and to detect subresources: a path with two or more wildcards names a
+// resource that is owned by the resource identified by its earlier
+// wildcards.
+func ExtractWildcards(path string) []string {
+	names := []string{}
+	var cur []rune
+	inWildcard := false
+	runes := []rune(path)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) && (runes[i+1] == '{' || runes[i+1] == '}') {
+			if inWildcard {
+				cur = append(cur, runes[i+1])
+			}
+			i++
+			continue
+		}
+		switch r {
+		case '{':
+			inWildcard = true
+			cur = cur[:0]
+		case '}':
+			if inWildcard {
+				names = append(names, string(cur))
+				inWildcard = false
+			}
+		default:
+			if inWildcard {
+				cur = append(cur, r)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,42 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractWildcards(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"no wildcards", "/foo/bar", []string{}},
+		{"single wildcard", "/foo/{bar}", []string{"bar"}},
+		{"mixed segments", "/foo/{bar}/baz/{qux}.json", []string{"bar", "qux"}},
+		{"adjacent wildcards", "/foo/{bar}{baz}", []string{"bar", "baz"}},
+		{"escaped braces ignored", `/foo/\{bar\}/{baz}`, []string{"baz"}},
+		{"empty path", "", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractWildcards(tt.path)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractWildcards(%q) = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iancoleman/strcase"
+)
+
+// Field describes a single field of a generated TypeDef.
+type Field struct {
+	// Name is the exported Go field name.
+	Name string
+	// JSONName is the field's JSON/YAML tag.
+	JSONName string
+	// GoType is the Go type the field is rendered with.
+	GoType string
+	// Required indicates the field must always be populated.
+	Required bool
+	// From records where the field's value comes from: "body" for a field
+	// taken from the operation request body, "path" for one populated
+	// from a path wildcard (metadata.name or an owner reference) rather
+	// than the request body, or "computed" for a Status field such as
+	// ACKResourceMetadata that is not supplied by the user.
+	From string
+	// Versions lists the API versions this field is present in. A nil or
+	// empty slice means the field is present in every requested version.
+	Versions []string
+}
+
+// TypeDef is a generated Go type (a Spec, Status, or similar struct).
+type TypeDef struct {
+	Name   string
+	Fields []*Field
+}
+
+// Resource is a single top-level or subresource CRD the generator emits.
+type Resource struct {
+	// Kind is the resource's exported Go/Kubernetes Kind name, e.g. "Policy".
+	Kind string
+	// Fields are the fields synthesized directly from the resource's path
+	// (see ExtractWildcards). ResourcesFromAPI adds no fields derived from
+	// the request or response body schemas.
+	Fields []*Field
+	// SourceOperations are the OpenAPI operations that were grouped
+	// together to produce this Resource. This is the resource's actual
+	// provenance: callers needing to know which OpenAPI tags apply to a
+	// Resource should inspect these operations directly rather than
+	// guessing from the Resource's Kind.
+	SourceOperations []*openapi3.Operation
+	// IsSubresource is true if this Resource is owned by another Resource,
+	// as detected by a path containing two or more wildcards.
+	IsSubresource bool
+	// ParentKind is the Kind of the owning Resource, set only when
+	// IsSubresource is true. The generated <kind>.go names this Kind in a
+	// comment; it does not set or check ownerReferences.
+	ParentKind string
+}
+
+// SpecTypeDefName returns the canonical name of a resource's Spec TypeDef.
+func SpecTypeDefName(kind string) string { return kind + "Spec" }
+
+// StatusTypeDefName returns the canonical name of a resource's Status TypeDef.
+func StatusTypeDefName(kind string) string { return kind + "Status" }
+
+// kindFromPath derives the exported Kind a path represents from its final
+// literal (non-wildcard) segment, e.g. "/roles/{name}/policies/{id}"
+// produces "Policy". Paths with no literal segments don't identify a
+// resource and return "".
+func kindFromPath(path string) string {
+	segs := literalPathSegments(path)
+	if len(segs) == 0 {
+		return ""
+	}
+	return strcase.ToCamel(Singularize(segs[len(segs)-1]))
+}
+
+// parentKindFromPath derives the Kind of the resource that owns a
+// subresource path, from the literal segment immediately preceding the
+// subresource's own (final) segment, e.g. "/roles/{name}/policies/{id}"
+// produces "Role". Paths with fewer than two literal segments return "".
+func parentKindFromPath(path string) string {
+	segs := literalPathSegments(path)
+	if len(segs) < 2 {
+		return ""
+	}
+	return strcase.ToCamel(Singularize(segs[len(segs)-2]))
+}
+
+// wildcardVersions looks up the OpenAPI path parameter matching
+// wildcardName on op and returns the "x-ack-versions" vendor extension
+// value on it, if any -- the versions of the API the field is present in.
+// A nil return means the field is present in every version.
+func wildcardVersions(op *openapi3.Operation, wildcardName string) []string {
+	for _, paramRef := range op.Parameters {
+		p := paramRef.Value
+		if p == nil || p.In != "path" || p.Name != wildcardName {
+			continue
+		}
+		ext, found := p.Extensions["x-ack-versions"]
+		if !found {
+			return nil
+		}
+		raw, ok := ext.(json.RawMessage)
+		if !ok {
+			return nil
+		}
+		var versions []string
+		if err := json.Unmarshal(raw, &versions); err != nil {
+			return nil
+		}
+		return versions
+	}
+	return nil
+}
+
+// upsertWildcardField adds a required, path-sourced field for wildcardName
+// to fields if one isn't already present, returning the updated slice.
+func upsertWildcardField(fields []*Field, wildcardName string, versions []string) []*Field {
+	fieldName := strcase.ToCamel(wildcardName)
+	for _, f := range fields {
+		if f.Name == fieldName {
+			return fields
+		}
+	}
+	return append(fields, &Field{
+		Name:     fieldName,
+		JSONName: wildcardName,
+		GoType:   "string",
+		Required: true,
+		From:     "path",
+		Versions: versions,
+	})
+}
+
+// ResourcesFromAPI builds the set of Resources the OpenAPI descriptor
+// describes. pathWildcards is the per-path output of ExtractWildcards (see
+// paths.go); it's used to (a) mark the fields corresponding to path
+// wildcards as required and sourced from the object's metadata.name or an
+// owner reference rather than the request body, and (b) detect
+// subresources: a path with two or more wildcards names a resource that is
+// owned by the resource identified by its earlier wildcards.
+func ResourcesFromAPI(api *openapi3.Swagger, pathWildcards map[string][]string) ([]*Resource, error) {
+	byKind := map[string]*Resource{}
+	var order []string
+
+	for path, item := range api.Paths {
+		kind := kindFromPath(path)
+		if kind == "" {
+			continue
+		}
+		res, ok := byKind[kind]
+		if !ok {
+			res = &Resource{Kind: kind}
+			byKind[kind] = res
+			order = append(order, kind)
+		}
+
+		wildcards := pathWildcards[path]
+		if len(wildcards) >= 2 {
+			res.IsSubresource = true
+			res.ParentKind = parentKindFromPath(path)
+		}
+
+		for _, op := range item.Operations() {
+			res.SourceOperations = append(res.SourceOperations, op)
+			for _, wildcard := range wildcards {
+				res.Fields = upsertWildcardField(res.Fields, wildcard, wildcardVersions(op, wildcard))
+			}
+		}
+	}
+
+	sort.Strings(order)
+	resources := make([]*Resource, 0, len(order))
+	for _, kind := range order {
+		resources = append(resources, byKind[kind])
+	}
+	return resources, nil
+}
+
+// FieldAppliesToVersion returns true if f is present in the named API
+// version, i.e. its Versions tag is empty (present in all versions) or
+// explicitly includes version. Callers generating per-version output (see
+// writeConversionGo) use this to tell which fields differ between any two
+// of the requested versions.
+func FieldAppliesToVersion(f *Field, version string) bool {
+	if len(f.Versions) == 0 {
+		return true
+	}
+	for _, v := range f.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeDefsFromAPI builds the Spec and Status TypeDefs for each Resource.
+// Each field keeps the Versions tag it was given in ResourcesFromAPI, so
+// that callers generating per-version output can tell which fields differ
+// between any two of the requested versions via FieldAppliesToVersion.
+func TypeDefsFromAPI(api *openapi3.Swagger, resources []*Resource, versions []string) ([]*TypeDef, error) {
+	var typeDefs []*TypeDef
+	for _, res := range resources {
+		typeDefs = append(typeDefs, &TypeDef{
+			Name:   SpecTypeDefName(res.Kind),
+			Fields: res.Fields,
+		})
+		typeDefs = append(typeDefs, &TypeDef{
+			Name: StatusTypeDefName(res.Kind),
+			Fields: []*Field{
+				{Name: "ACKResourceMetadata", JSONName: "ackResourceMetadata", GoType: "*ackv1alpha1.ResourceMetadata", From: "computed"},
+			},
+		})
+	}
+	return typeDefs, nil
+}
@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource
+
+import "testing"
+
+func TestKindFromPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"top-level collection", "/policies", "Policy"},
+		{"top-level item", "/policies/{id}", "Policy"},
+		{"subresource item", "/roles/{name}/policies/{id}", "Policy"},
+		{"no literal segments", "/{id}", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kindFromPath(tt.path); got != tt.want {
+				t.Errorf("kindFromPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParentKindFromPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"subresource item", "/roles/{name}/policies/{id}", "Role"},
+		{"no literal segments", "/{id}", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parentKindFromPath(tt.path); got != tt.want {
+				t.Errorf("parentKindFromPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldAppliesToVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   *Field
+		version string
+		want    bool
+	}{
+		{"no versions tag applies everywhere", &Field{}, "v1alpha1", true},
+		{"matching version", &Field{Versions: []string{"v1alpha1", "v1beta1"}}, "v1beta1", true},
+		{"non-matching version", &Field{Versions: []string{"v1alpha1"}}, "v1beta1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldAppliesToVersion(tt.field, tt.version); got != tt.want {
+				t.Errorf("FieldAppliesToVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
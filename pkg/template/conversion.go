@@ -0,0 +1,44 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package template
+
+import "github.com/aws/aws-service-operator-k8s/pkg/resource"
+
+const conversionGoBuiltin = `// Code generated by ack-generate. DO NOT EDIT.
+
+package {{ .SpokeVersion }}
+
+// Conversion stub between {{ .SpokeVersion }} and the {{ .HubVersion }} hub
+// version. It lists, per type, the fields whose presence differs between the
+// two versions; it defines no ConvertTo or ConvertFrom methods.
+{{ range $name, $fields := .DivergentFields }}
+// {{ $name }} fields not present in both {{ $.HubVersion }} and {{ $.SpokeVersion }}: {{ range $fields }}{{ . }} {{ end }}
+{{ end }}`
+
+// ConversionTemplateVars supplies conversion.go.tpl's template variables.
+type ConversionTemplateVars struct {
+	HubVersion   string
+	SpokeVersion string
+	TypeDefs     []*resource.TypeDef
+	// DivergentFields maps each TypeDef name to the fields whose presence
+	// differs between HubVersion and SpokeVersion, i.e. the fields a
+	// conversion between the two versions cannot copy straight across.
+	DivergentFields map[string][]string
+}
+
+// NewConversionTemplate loads conversion.go.tpl from searchPath, falling
+// back to the built-in source if no override is found.
+func NewConversionTemplate(searchPath []string) (*Template, error) {
+	return load(searchPath, "conversion.go.tpl", conversionGoBuiltin)
+}
@@ -0,0 +1,34 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package template
+
+const docGoBuiltin = `// Code generated by ack-generate. DO NOT EDIT.
+
+// Package {{ .APIVersion }} contains API Schema definitions for the
+// {{ .APIGroup }} API group.
+// +groupName={{ .APIGroup }}
+package {{ .APIVersion }}
+`
+
+// DocTemplateVars supplies doc.go.tpl's template variables.
+type DocTemplateVars struct {
+	APIVersion string
+	APIGroup   string
+}
+
+// NewDocTemplate loads doc.go.tpl from searchPath, falling back to the
+// built-in source if no override is found.
+func NewDocTemplate(searchPath []string) (*Template, error) {
+	return load(searchPath, "doc.go.tpl", docGoBuiltin)
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package template
+
+const groupVersionInfoGoBuiltin = `// Code generated by ack-generate. DO NOT EDIT.
+
+// Package {{ .APIVersion }} contains API Schema definitions for the
+// {{ .APIGroup }} API group.
+// +kubebuilder:object:generate=true
+// +groupName={{ .APIGroup }}
+package {{ .APIVersion }}
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is the API group and version used to register these
+	// objects.
+	GroupVersion = schema.GroupVersion{Group: "{{ .APIGroup }}", Version: "{{ .APIVersion }}"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+`
+
+// GroupVersionInfoTemplateVars supplies groupversion_info.go.tpl's template
+// variables.
+type GroupVersionInfoTemplateVars struct {
+	APIVersion string
+	APIGroup   string
+}
+
+// NewGroupVersionInfoTemplate loads groupversion_info.go.tpl from
+// searchPath, falling back to the built-in source if no override is found.
+func NewGroupVersionInfoTemplate(searchPath []string) (*Template, error) {
+	return load(searchPath, "groupversion_info.go.tpl", groupVersionInfoGoBuiltin)
+}
@@ -0,0 +1,50 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package template
+
+import "github.com/aws/aws-service-operator-k8s/pkg/resource"
+
+const resourceGoBuiltin = `// Code generated by ack-generate. DO NOT EDIT.
+
+package {{ .APIVersion }}
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// {{ .Resource.Kind }} is the Schema for the {{ .Resource.Kind }} API.
+// +kubebuilder:object:root=true
+type {{ .Resource.Kind }} struct {
+	metav1.TypeMeta   ` + "`" + `json:",inline"` + "`" + `
+	metav1.ObjectMeta ` + "`" + `json:"metadata,omitempty"` + "`" + `
+
+	Spec   {{ .Resource.Kind }}Spec   ` + "`" + `json:"spec,omitempty"` + "`" + `
+	Status {{ .Resource.Kind }}Status ` + "`" + `json:"status,omitempty"` + "`" + `
+}
+{{ if .Resource.IsSubresource }}
+// {{ .Resource.Kind }} is a subresource of {{ .Resource.ParentKind }}. Nothing
+// in this file sets or validates ObjectMeta.OwnerReferences.
+{{ end }}`
+
+// ResourceTemplateVars supplies resource.go.tpl's template variables.
+type ResourceTemplateVars struct {
+	APIVersion string
+	Resource   *resource.Resource
+}
+
+// NewResourceTemplate loads resource.go.tpl from searchPath, falling back
+// to the built-in source if no override is found.
+func NewResourceTemplate(searchPath []string) (*Template, error) {
+	return load(searchPath, "resource.go.tpl", resourceGoBuiltin)
+}
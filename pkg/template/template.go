@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package template loads the Go templates the ack-generate types command
+// renders generated source from. Each named template (doc.go.tpl,
+// types.go.tpl, ...) has a built-in source baked into this package, and can
+// be overridden by placing a like-named file in one of the directories
+// supplied via --template-dir.
+package template
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// Template wraps a parsed template together with the directory it was
+// resolved from, so that callers can report exactly what was loaded
+// instead of re-deriving it themselves.
+type Template struct {
+	tpl *template.Template
+	// ResolvedDir is the directory the template's source was loaded from,
+	// or "" if no override was found and the built-in source was used.
+	ResolvedDir string
+}
+
+// Execute renders the template against data, writing the result to w.
+func (t *Template) Execute(w io.Writer, data interface{}) error {
+	return t.tpl.Execute(w, data)
+}
+
+// ResolveDir returns the first directory in searchPath containing a file
+// named name, or "" if none do, meaning the built-in template is used.
+// list-templates calls this directly so that its output can never drift
+// from what load resolves at generation time.
+func ResolveDir(searchPath []string, name string) string {
+	for _, dir := range searchPath {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+// load resolves name against searchPath (see ResolveDir), parsing the
+// override file's contents if one was found, or the supplied built-in
+// source otherwise.
+func load(searchPath []string, name string, builtin string) (*Template, error) {
+	dir := ResolveDir(searchPath, name)
+	src := builtin
+	if dir != "" {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		src = string(b)
+	}
+	tpl, err := template.New(name).Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{tpl: tpl, ResolvedDir: dir}, nil
+}
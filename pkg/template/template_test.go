@@ -0,0 +1,60 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "doc.go.tpl"), []byte("package x"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ResolveDir([]string{dir}, "doc.go.tpl"); got != dir {
+		t.Errorf("ResolveDir() = %q, want %q", got, dir)
+	}
+	if got := ResolveDir([]string{t.TempDir()}, "doc.go.tpl"); got != "" {
+		t.Errorf("ResolveDir() = %q, want \"\" (no override present)", got)
+	}
+}
+
+func TestNewDocTemplateFallsBackToBuiltin(t *testing.T) {
+	tpl, err := NewDocTemplate([]string{t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tpl.ResolvedDir != "" {
+		t.Errorf("ResolvedDir = %q, want \"\" (built-in source used)", tpl.ResolvedDir)
+	}
+}
+
+func TestNewDocTemplateUsesOverride(t *testing.T) {
+	dir := t.TempDir()
+	const override = "package overridden\n"
+	if err := os.WriteFile(filepath.Join(dir, "doc.go.tpl"), []byte(override), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	tpl, err := NewDocTemplate([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tpl.ResolvedDir != dir {
+		t.Errorf("ResolvedDir = %q, want %q", tpl.ResolvedDir, dir)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package template
+
+import "github.com/aws/aws-service-operator-k8s/pkg/resource"
+
+const typesGoBuiltin = `// Code generated by ack-generate. DO NOT EDIT.
+
+package {{ .APIVersion }}
+{{ range .TypeDefs }}
+// {{ .Name }} is a generated type definition.
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .GoType }} ` + "`" + `json:"{{ .JSONName }},omitempty"` + "`" + `
+{{- end }}
+}
+{{ end }}`
+
+// TypesTemplateVars supplies types.go.tpl's template variables.
+type TypesTemplateVars struct {
+	APIVersion string
+	TypeDefs   []*resource.TypeDef
+}
+
+// NewTypesTemplate loads types.go.tpl from searchPath, falling back to the
+// built-in source if no override is found.
+func NewTypesTemplate(searchPath []string) (*Template, error) {
+	return load(searchPath, "types.go.tpl", typesGoBuiltin)
+}